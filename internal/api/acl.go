@@ -0,0 +1,198 @@
+package api
+
+import (
+	"ask-me-anything/internal/schema"
+	"ask-me-anything/internal/store/pgstore"
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+)
+
+// compiledACL is the in-memory, read-optimized form of a room's server_acls
+// row: allow/deny glob patterns matched against the client IP with
+// path.Match semantics, plus a set of banned participant identifiers. Deny
+// always wins over allow, and an empty allow list means "allow everyone"
+// unless a deny glob or ban says otherwise — allow-then-deny precedence is
+// the classic footgun with ACLs, so deny is checked first, unconditionally.
+type compiledACL struct {
+	allow              []string
+	deny               []string
+	bannedParticipants map[string]struct{}
+}
+
+func newCompiledACL(allow, deny, bannedParticipants []string) *compiledACL {
+	banned := make(map[string]struct{}, len(bannedParticipants))
+	for _, id := range bannedParticipants {
+		banned[id] = struct{}{}
+	}
+
+	return &compiledACL{allow: allow, deny: deny, bannedParticipants: banned}
+}
+
+func (a *compiledACL) allows(clientIP, participantID string) bool {
+	if participantID != "" {
+		if _, banned := a.bannedParticipants[participantID]; banned {
+			return false
+		}
+	}
+
+	for _, pattern := range a.deny {
+		if matched, _ := path.Match(pattern, clientIP); matched {
+			return false
+		}
+	}
+
+	if len(a.allow) == 0 {
+		return true
+	}
+
+	for _, pattern := range a.allow {
+		if matched, _ := path.Match(pattern, clientIP); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// aclCache holds the compiled ACL for every room that has one, refreshed on
+// write so the hot path (handleSubscribe, handleCreateRoomMessage, ...)
+// stays a couple of map lookups instead of a round-trip to Postgres.
+type aclCache struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*compiledACL
+}
+
+func newACLCache() *aclCache {
+	return &aclCache{byID: make(map[uuid.UUID]*compiledACL)}
+}
+
+func (c *aclCache) set(roomID uuid.UUID, acl *compiledACL) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byID[roomID] = acl
+}
+
+func (c *aclCache) get(roomID uuid.UUID) (*compiledACL, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	acl, ok := c.byID[roomID]
+	return acl, ok
+}
+
+// checkACL reports whether clientIP/participantID may act on roomID. Rooms
+// without a cached ACL allow everyone; loadACLCache primes the cache for
+// every room that does have one stored, so this only happens for rooms that
+// genuinely have none.
+func (h *Handler) checkACL(roomID uuid.UUID, clientIP, participantID string) bool {
+	acl, ok := h.acls.get(roomID)
+	if !ok {
+		return true
+	}
+	return acl.allows(clientIP, participantID)
+}
+
+func (h *Handler) writeIfForbidden(c *gin.Context, roomID uuid.UUID) bool {
+	if h.checkACL(roomID, c.ClientIP(), h.optionalUserID(c)) {
+		return false
+	}
+
+	c.PureJSON(http.StatusForbidden, schema.ForbiddenOutput{Error: "forbidden by room acl"})
+	return true
+}
+
+// loadACLCache primes the cache with every ACL already persisted in
+// pgstore. Without this, an ACL written before the current process started
+// would enforce nothing until the next PUT — a fail-open gap for a feature
+// whose entire point is restricting access.
+func (h *Handler) loadACLCache(ctx context.Context) error {
+	acls, err := h.queries.GetServerACLs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, acl := range acls {
+		h.acls.set(acl.RoomID, newCompiledACL(acl.Allow, acl.Deny, acl.BannedParticipants))
+	}
+	return nil
+}
+
+func (h *Handler) handlePutRoomACL(c *gin.Context) {
+	var uri schema.GetRoomByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var body schema.PutRoomACLInput
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roomID := uuid.MustParse(uri.RoomID)
+	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "room not found")
+			return
+		}
+
+		slog.Error("failed to get room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err := h.queries.UpsertServerACL(c.Request.Context(), pgstore.UpsertServerACLParams{
+		RoomID:             roomID,
+		Allow:              body.Allow,
+		Deny:               body.Deny,
+		BannedParticipants: body.BannedParticipants,
+	}); err != nil {
+		slog.Error("failed to upsert server acl", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	h.acls.set(roomID, newCompiledACL(body.Allow, body.Deny, body.BannedParticipants))
+
+	c.PureJSON(http.StatusOK, schema.GetRoomACLOutput{
+		Allow:              body.Allow,
+		Deny:               body.Deny,
+		BannedParticipants: body.BannedParticipants,
+	})
+}
+
+func (h *Handler) handleGetRoomACL(c *gin.Context) {
+	var uri schema.GetRoomByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roomID := uuid.MustParse(uri.RoomID)
+	acl, err := h.queries.GetServerACL(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusOK, schema.GetRoomACLOutput{})
+			return
+		}
+
+		slog.Error("failed to get server acl", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.PureJSON(http.StatusOK, schema.GetRoomACLOutput{
+		Allow:              acl.Allow,
+		Deny:               acl.Deny,
+		BannedParticipants: acl.BannedParticipants,
+	})
+}
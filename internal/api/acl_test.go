@@ -0,0 +1,71 @@
+package api
+
+import "testing"
+
+func TestCompiledACLAllows(t *testing.T) {
+	tests := []struct {
+		name          string
+		allow         []string
+		deny          []string
+		banned        []string
+		clientIP      string
+		participantID string
+		want          bool
+	}{
+		{
+			name:     "no rules allows everyone",
+			clientIP: "10.0.0.1",
+			want:     true,
+		},
+		{
+			name:     "allow glob matches",
+			allow:    []string{"10.0.0.*"},
+			clientIP: "10.0.0.5",
+			want:     true,
+		},
+		{
+			name:     "allow glob does not match",
+			allow:    []string{"10.0.0.*"},
+			clientIP: "192.168.0.5",
+			want:     false,
+		},
+		{
+			name:     "deny wins over a matching allow",
+			allow:    []string{"10.0.0.*"},
+			deny:     []string{"10.0.0.5"},
+			clientIP: "10.0.0.5",
+			want:     false,
+		},
+		{
+			name:     "deny applies even with an empty allow list",
+			deny:     []string{"10.0.0.*"},
+			clientIP: "10.0.0.5",
+			want:     false,
+		},
+		{
+			name:          "banned participant is denied despite a matching allow",
+			allow:         []string{"*"},
+			banned:        []string{"user-1"},
+			clientIP:      "10.0.0.5",
+			participantID: "user-1",
+			want:          false,
+		},
+		{
+			name:          "ban list does not affect other participants",
+			allow:         []string{"*"},
+			banned:        []string{"user-1"},
+			clientIP:      "10.0.0.5",
+			participantID: "user-2",
+			want:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := newCompiledACL(tt.allow, tt.deny, tt.banned)
+			if got := acl.allows(tt.clientIP, tt.participantID); got != tt.want {
+				t.Errorf("allows(%q, %q) = %v, want %v", tt.clientIP, tt.participantID, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"log/slog"
 	"net/http"
 	"sync"
@@ -19,20 +20,45 @@ const (
 	KindMessageReactionIncreased = "message_reaction_increased"
 	KindMessageReactionDecreased = "message_reaction_decreased"
 	KindMessageAnswered          = "message_answered"
+	KindMessageRedacted          = "message_redacted"
+	KindRoomClosed               = "room_closed"
 )
 
+// Server-side bounds for the `limit` query parameter on message listing
+// routes, so a popular AMA can't be asked to return its entire history (or
+// zero messages) in one response.
+const (
+	defaultMessagesLimit = 50
+	maxMessagesLimit     = 100
+)
+
+// subscriber tracks what's needed to tear down a WebSocket connection and,
+// once it has authenticated, which user it belongs to so notifyClients can
+// eventually do per-user filtering.
+type subscriber struct {
+	cancel context.CancelFunc
+	userID string
+}
+
 type Handler struct {
 	queries     *pgstore.Queries
 	router      *gin.Engine
 	upgrader    websocket.Upgrader
-	subscribers map[string]map[*websocket.Conn]context.CancelFunc
+	subscribers map[string]map[*websocket.Conn]subscriber
 	mu          *sync.Mutex
+	broker      Broker
+	acls        *aclCache
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.router.ServeHTTP(w, r)
 }
 
+// NewHandler wires up the router with the in-memory broker, which is the
+// right default for a single instance and for tests. Deployments running
+// more than one instance behind a load balancer should build a *PgBroker and
+// attach it with SetBroker so messages published on one instance also reach
+// subscribers connected to another.
 func NewHandler(q *pgstore.Queries) http.Handler {
 	router := gin.New()
 
@@ -40,8 +66,14 @@ func NewHandler(q *pgstore.Queries) http.Handler {
 		queries:     q,
 		router:      router,
 		upgrader:    websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
-		subscribers: make(map[string]map[*websocket.Conn]context.CancelFunc),
+		subscribers: make(map[string]map[*websocket.Conn]subscriber),
 		mu:          &sync.Mutex{},
+		acls:        newACLCache(),
+	}
+	handler.broker = newMemoryBroker(handler)
+
+	if err := handler.loadACLCache(context.Background()); err != nil {
+		slog.Error("failed to load server acls into cache", "error", err)
 	}
 
 	router.Use(gin.Recovery(), gin.Logger())
@@ -51,12 +83,24 @@ func NewHandler(q *pgstore.Queries) http.Handler {
 
 	v1.GET("/subscribe/:room_id", handler.handleSubscribe)
 
+	auth := v1.Group("/auth")
+	{
+		auth.POST("/register", handler.handleRegister)
+		auth.POST("/login", handler.handleLogin)
+	}
+
+	moderatorOnly := []gin.HandlerFunc{handler.AuthMiddleware(), handler.RequireRole("moderator", "owner")}
+
 	rooms := v1.Group("/rooms")
 	{
-		rooms.POST("/", handler.handleCreateRoom)
+		rooms.POST("/", handler.AuthMiddleware(), handler.handleCreateRoom)
 		rooms.GET("/", handler.handleGetRooms)
 
 		rooms.GET("/:room_id", handler.handleGetRoom)
+		rooms.PATCH("/:room_id/close", append(moderatorOnly, handler.handleCloseRoom)...)
+		rooms.POST("/:room_id/upgrade", append(moderatorOnly, handler.handleUpgradeRoom)...)
+		rooms.PUT("/:room_id/acl", append(moderatorOnly, handler.handlePutRoomACL)...)
+		rooms.GET("/:room_id/acl", append(moderatorOnly, handler.handleGetRoomACL)...)
 	}
 
 	messages := rooms.Group("/:room_id/messages")
@@ -64,15 +108,24 @@ func NewHandler(q *pgstore.Queries) http.Handler {
 		messages.POST("/", handler.handleCreateRoomMessage)
 		messages.GET("/", handler.handleGetRoomMessages)
 
+		messages.GET("/top", handler.handleGetTopRoomMessages)
 		messages.GET("/:message_id", handler.handleGetRoomMessage)
+		messages.DELETE("/:message_id", append(moderatorOnly, handler.handleRedactMessage)...)
 		messages.PATCH("/:message_id/react", handler.handleReactToMessage)
 		messages.DELETE("/:message_id/react", handler.handleRemoveReactionFromMessage)
-		messages.PATCH("/:message_id/answer", handler.handleMarkMessageAsAnswered)
+		messages.PATCH("/:message_id/answer", append(moderatorOnly, handler.handleMarkMessageAsAnswered)...)
 	}
 
 	return handler
 }
 
+// SetBroker replaces the handler's broker. NewHandler returns an http.Handler,
+// so callers that need a non-default broker type-assert back to *Handler
+// first, e.g. `api.NewHandler(q).(*api.Handler).SetBroker(pgBroker)`.
+func (h *Handler) SetBroker(b Broker) {
+	h.broker = b
+}
+
 func (h *Handler) notifyClients(msg schema.Message) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -82,10 +135,10 @@ func (h *Handler) notifyClients(msg schema.Message) {
 		return
 	}
 
-	for conn, cancel := range subscribers {
+	for conn, sub := range subscribers {
 		if err := conn.WriteJSON(msg); err != nil {
 			slog.Error("failed to send message to client", "error", err)
-			cancel()
+			sub.cancel()
 		}
 	}
 }
@@ -98,7 +151,7 @@ func (h *Handler) handleSubscribe(c *gin.Context) {
 	}
 
 	roomID := uuid.MustParse(uri.RoomID)
-	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	room, err := h.queries.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			c.PureJSON(http.StatusNotFound, "room not found")
@@ -110,6 +163,16 @@ func (h *Handler) handleSubscribe(c *gin.Context) {
 		return
 	}
 
+	if h.writeIfRoomClosed(c, room) {
+		return
+	}
+
+	if h.writeIfForbidden(c, roomID) {
+		return
+	}
+
+	userID := h.optionalUserID(c)
+
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		slog.Warn("failed to upgrade connection", "error", err)
@@ -121,11 +184,11 @@ func (h *Handler) handleSubscribe(c *gin.Context) {
 
 	h.mu.Lock()
 	if _, ok := h.subscribers[roomID.String()]; !ok {
-		h.subscribers[roomID.String()] = make(map[*websocket.Conn]context.CancelFunc)
+		h.subscribers[roomID.String()] = make(map[*websocket.Conn]subscriber)
 	}
 
-	slog.Info("new client connected", "room_id", roomID.String(), "client_ip", c.ClientIP())
-	h.subscribers[roomID.String()][conn] = cancel
+	slog.Info("new client connected", "room_id", roomID.String(), "client_ip", c.ClientIP(), "user_id", userID)
+	h.subscribers[roomID.String()][conn] = subscriber{cancel: cancel, userID: userID}
 	h.mu.Unlock()
 
 	<-ctx.Done()
@@ -142,6 +205,8 @@ func (h *Handler) handleCreateRoom(c *gin.Context) {
 		return
 	}
 
+	userID, _ := c.Get("user_id")
+
 	roomID, err := h.queries.InsertRoom(c.Request.Context(), body.Theme)
 	if err != nil {
 		slog.Error("failed to insert room", "error", err)
@@ -149,6 +214,16 @@ func (h *Handler) handleCreateRoom(c *gin.Context) {
 		return
 	}
 
+	if err := h.queries.InsertRoomMember(c.Request.Context(), pgstore.InsertRoomMemberParams{
+		RoomID: roomID,
+		UserID: uuid.MustParse(userID.(string)),
+		Role:   "owner",
+	}); err != nil {
+		slog.Error("failed to insert room owner", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
 	c.PureJSON(http.StatusCreated, schema.CreateRoomOutput{ID: roomID.String()})
 }
 
@@ -204,7 +279,7 @@ func (h *Handler) handleCreateRoomMessage(c *gin.Context) {
 	}
 
 	roomID := uuid.MustParse(uri.RoomID)
-	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	room, err := h.queries.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			c.PureJSON(http.StatusNotFound, "room not found")
@@ -216,6 +291,14 @@ func (h *Handler) handleCreateRoomMessage(c *gin.Context) {
 		return
 	}
 
+	if h.writeIfRoomClosed(c, room) {
+		return
+	}
+
+	if h.writeIfForbidden(c, roomID) {
+		return
+	}
+
 	var body schema.CreateMessageInput
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.PureJSON(http.StatusBadRequest, err.Error())
@@ -232,7 +315,7 @@ func (h *Handler) handleCreateRoomMessage(c *gin.Context) {
 		return
 	}
 
-	go h.notifyClients(schema.Message{
+	go h.broker.Publish(schema.Message{
 		Kind:   KindMessageCreated,
 		RoomID: roomID.String(),
 		Value: schema.MessageCreatedEvent{
@@ -251,6 +334,12 @@ func (h *Handler) handleGetRoomMessages(c *gin.Context) {
 		return
 	}
 
+	var query schema.GetRoomMessagesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	roomID := uuid.MustParse(uri.RoomID)
 	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
@@ -264,31 +353,118 @@ func (h *Handler) handleGetRoomMessages(c *gin.Context) {
 		return
 	}
 
-	messages, err := h.queries.GetRoomMessages(c.Request.Context(), roomID)
+	params := pgstore.GetRoomMessagesPaginatedParams{
+		RoomID: roomID,
+		Limit:  int32(clampMessagesLimit(query.Limit)),
+	}
+
+	if query.Before != "" {
+		createdAt, id, err := decodeCursor(query.Before)
+		if err != nil {
+			c.PureJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		params.Before = pgtype.Timestamptz{Time: createdAt, Valid: true}
+		params.BeforeID = id
+	}
+
+	if query.After != "" {
+		createdAt, id, err := decodeCursor(query.After)
+		if err != nil {
+			c.PureJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		params.After = pgtype.Timestamptz{Time: createdAt, Valid: true}
+		params.AfterID = id
+	}
+
+	messages, err := h.queries.GetRoomMessagesPaginated(c.Request.Context(), params)
 	if err != nil {
 		slog.Error("failed to get room messages", "error", err)
 		c.PureJSON(http.StatusInternalServerError, "something went wrong")
 		return
 	}
 
-	if messages == nil {
-		c.PureJSON(http.StatusOK, []schema.GetMessageOutput{})
+	output := schema.GetRoomMessagesOutput{Messages: []schema.GetMessageOutput{}}
+	for _, message := range messages {
+		output.Messages = append(output.Messages, schema.GetMessageOutput{
+			ID:            message.ID.String(),
+			Message:       message.Message,
+			ReactionCount: message.ReactionCount,
+			Answered:      message.Answered,
+			Redacted:      message.RedactedAt.Valid,
+		})
+	}
+
+	if len(messages) > 0 {
+		first, last := messages[0], messages[len(messages)-1]
+		output.Prev = encodeCursor(first.CreatedAt.Time, first.ID)
+		output.Next = encodeCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	c.PureJSON(http.StatusOK, output)
+}
+
+func (h *Handler) handleGetTopRoomMessages(c *gin.Context) {
+	var uri schema.GetRoomByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var query schema.GetTopRoomMessagesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var output []schema.GetMessageOutput
+	roomID := uuid.MustParse(uri.RoomID)
+	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "room not found")
+			return
+		}
+
+		slog.Error("failed to get room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	messages, err := h.queries.GetTopRoomMessages(c.Request.Context(), pgstore.GetTopRoomMessagesParams{
+		RoomID: roomID,
+		Limit:  int32(clampMessagesLimit(query.Limit)),
+	})
+	if err != nil {
+		slog.Error("failed to get top room messages", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	output := make([]schema.GetMessageOutput, 0, len(messages))
 	for _, message := range messages {
 		output = append(output, schema.GetMessageOutput{
 			ID:            message.ID.String(),
 			Message:       message.Message,
 			ReactionCount: message.ReactionCount,
 			Answered:      message.Answered,
+			Redacted:      message.RedactedAt.Valid,
 		})
 	}
 
 	c.PureJSON(http.StatusOK, output)
 }
 
+func clampMessagesLimit(limit int) int {
+	if limit <= 0 {
+		return defaultMessagesLimit
+	}
+	if limit > maxMessagesLimit {
+		return maxMessagesLimit
+	}
+	return limit
+}
+
 func (h *Handler) handleGetRoomMessage(c *gin.Context) {
 	var uri schema.GetMessageByIDInput
 	if err := c.ShouldBindUri(&uri); err != nil {
@@ -327,6 +503,7 @@ func (h *Handler) handleGetRoomMessage(c *gin.Context) {
 		Message:       message.Message,
 		ReactionCount: message.ReactionCount,
 		Answered:      message.Answered,
+		Redacted:      message.RedactedAt.Valid,
 	})
 }
 
@@ -338,7 +515,7 @@ func (h *Handler) handleReactToMessage(c *gin.Context) {
 	}
 
 	roomID := uuid.MustParse(uri.RoomID)
-	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	room, err := h.queries.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			c.PureJSON(http.StatusNotFound, "room not found")
@@ -350,8 +527,16 @@ func (h *Handler) handleReactToMessage(c *gin.Context) {
 		return
 	}
 
+	if h.writeIfRoomClosed(c, room) {
+		return
+	}
+
+	if h.writeIfForbidden(c, roomID) {
+		return
+	}
+
 	messageID := uuid.MustParse(uri.MessageID)
-	_, err = h.queries.GetMessage(c.Request.Context(), messageID)
+	message, err := h.queries.GetMessage(c.Request.Context(), messageID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			c.PureJSON(http.StatusNotFound, "message not found")
@@ -363,6 +548,10 @@ func (h *Handler) handleReactToMessage(c *gin.Context) {
 		return
 	}
 
+	if h.writeIfMessageRedacted(c, message) {
+		return
+	}
+
 	count, err := h.queries.ReactToMessage(c.Request.Context(), messageID)
 	if err != nil {
 		slog.Error("failed to react to message", "error", err)
@@ -370,7 +559,7 @@ func (h *Handler) handleReactToMessage(c *gin.Context) {
 		return
 	}
 
-	go h.notifyClients(schema.Message{
+	go h.broker.Publish(schema.Message{
 		Kind:   KindMessageReactionIncreased,
 		RoomID: roomID.String(),
 		Value: schema.MessageReactionCountChangedEvent{
@@ -384,13 +573,21 @@ func (h *Handler) handleReactToMessage(c *gin.Context) {
 	})
 }
 
-func (h *Handler) handleRemoveReactionFromMessage(c *gin.Context) {
+func (h *Handler) handleRedactMessage(c *gin.Context) {
 	var uri schema.GetMessageByIDInput
 	if err := c.ShouldBindUri(&uri); err != nil {
 		c.PureJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
+	var body schema.RedactMessageInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.PureJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	roomID := uuid.MustParse(uri.RoomID)
 	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
 	if err != nil {
@@ -417,6 +614,64 @@ func (h *Handler) handleRemoveReactionFromMessage(c *gin.Context) {
 		return
 	}
 
+	if err := h.queries.RedactMessage(c.Request.Context(), pgstore.RedactMessageParams{
+		ID:     messageID,
+		Reason: body.Reason,
+	}); err != nil {
+		slog.Error("failed to redact message", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	go h.broker.Publish(schema.Message{
+		Kind:   KindMessageRedacted,
+		RoomID: roomID.String(),
+		Value: schema.MessageRedactedEvent{
+			ID:     messageID.String(),
+			Reason: body.Reason,
+		},
+	})
+
+	c.PureJSON(http.StatusOK, nil)
+}
+
+func (h *Handler) handleRemoveReactionFromMessage(c *gin.Context) {
+	var uri schema.GetMessageByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roomID := uuid.MustParse(uri.RoomID)
+	_, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "room not found")
+			return
+		}
+
+		slog.Error("failed to get room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	messageID := uuid.MustParse(uri.MessageID)
+	message, err := h.queries.GetMessage(c.Request.Context(), messageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "message not found")
+			return
+		}
+
+		slog.Error("failed to get message", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if h.writeIfMessageRedacted(c, message) {
+		return
+	}
+
 	count, err := h.queries.RemoveReactionFromMessage(c.Request.Context(), messageID)
 	if err != nil {
 		slog.Error("failed to remove reaction from message", "error", err)
@@ -424,7 +679,7 @@ func (h *Handler) handleRemoveReactionFromMessage(c *gin.Context) {
 		return
 	}
 
-	go h.notifyClients(schema.Message{
+	go h.broker.Publish(schema.Message{
 		Kind:   KindMessageReactionDecreased,
 		RoomID: roomID.String(),
 		Value: schema.MessageReactionCountChangedEvent{
@@ -475,7 +730,7 @@ func (h *Handler) handleMarkMessageAsAnswered(c *gin.Context) {
 		return
 	}
 
-	go h.notifyClients(schema.Message{
+	go h.broker.Publish(schema.Message{
 		Kind:   KindMessageAnswered,
 		RoomID: roomID.String(),
 		Value: schema.MessageAnsweredEvent{
@@ -485,3 +740,163 @@ func (h *Handler) handleMarkMessageAsAnswered(c *gin.Context) {
 
 	c.PureJSON(http.StatusOK, nil)
 }
+
+// writeIfRoomClosed writes the 409 response for a room that has already
+// been closed and reports whether it did so, so callers can bail out early.
+func (h *Handler) writeIfRoomClosed(c *gin.Context, room pgstore.Room) bool {
+	if !room.ClosedAt.Valid {
+		return false
+	}
+
+	var successorRoomID string
+	if room.SuccessorRoomID.Valid {
+		successorRoomID = room.SuccessorRoomID.UUID.String()
+	}
+
+	c.PureJSON(http.StatusConflict, schema.RoomConflictOutput{
+		Error:           "room is closed",
+		SuccessorRoomID: successorRoomID,
+	})
+	return true
+}
+
+// writeIfMessageRedacted writes the 409 response for a message that has
+// already been redacted and reports whether it did so, so callers can bail
+// out early — redaction freezes a message's reaction count along with its
+// text.
+func (h *Handler) writeIfMessageRedacted(c *gin.Context, message pgstore.Message) bool {
+	if !message.RedactedAt.Valid {
+		return false
+	}
+
+	c.PureJSON(http.StatusConflict, schema.MessageConflictOutput{Error: "message has been redacted"})
+	return true
+}
+
+// evacuateRoom writes a final KindRoomClosed event to every local subscriber
+// of roomID and then cancels their connections. Only subscribers connected
+// to this process are reached: the event is not published through the
+// Handler's broker, so subscribers connected to other instances receive no
+// KindRoomClosed event and their connections stay open.
+func (h *Handler) evacuateRoom(roomID uuid.UUID, successorRoomID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for conn, sub := range h.subscribers[roomID.String()] {
+		if err := conn.WriteJSON(schema.Message{
+			Kind:   KindRoomClosed,
+			RoomID: roomID.String(),
+			Value:  schema.RoomClosedEvent{SuccessorRoomID: successorRoomID},
+		}); err != nil {
+			slog.Warn("failed to send room closed event to client", "error", err)
+		}
+		sub.cancel()
+	}
+}
+
+func (h *Handler) handleCloseRoom(c *gin.Context) {
+	var uri schema.GetRoomByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var body schema.CloseRoomInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.PureJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	roomID := uuid.MustParse(uri.RoomID)
+	room, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "room not found")
+			return
+		}
+
+		slog.Error("failed to get room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if h.writeIfRoomClosed(c, room) {
+		return
+	}
+
+	var successorRoomID uuid.NullUUID
+	if body.SuccessorRoomID != "" {
+		successorRoomID = uuid.NullUUID{UUID: uuid.MustParse(body.SuccessorRoomID), Valid: true}
+	}
+
+	if err := h.queries.CloseRoom(c.Request.Context(), pgstore.CloseRoomParams{
+		ID:              roomID,
+		SuccessorRoomID: successorRoomID,
+	}); err != nil {
+		slog.Error("failed to close room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	h.evacuateRoom(roomID, body.SuccessorRoomID)
+
+	c.PureJSON(http.StatusOK, nil)
+}
+
+func (h *Handler) handleUpgradeRoom(c *gin.Context) {
+	var uri schema.GetRoomByIDInput
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roomID := uuid.MustParse(uri.RoomID)
+	room, err := h.queries.GetRoom(c.Request.Context(), roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusNotFound, "room not found")
+			return
+		}
+
+		slog.Error("failed to get room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if h.writeIfRoomClosed(c, room) {
+		return
+	}
+
+	successorRoomID, err := h.queries.InsertRoom(c.Request.Context(), room.Theme)
+	if err != nil {
+		slog.Error("failed to insert successor room", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.queries.InsertRoomMember(c.Request.Context(), pgstore.InsertRoomMemberParams{
+		RoomID: successorRoomID,
+		UserID: uuid.MustParse(userID.(string)),
+		Role:   "owner",
+	}); err != nil {
+		slog.Error("failed to insert successor room owner", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err := h.queries.CloseRoom(c.Request.Context(), pgstore.CloseRoomParams{
+		ID:              roomID,
+		SuccessorRoomID: uuid.NullUUID{UUID: successorRoomID, Valid: true},
+	}); err != nil {
+		slog.Error("failed to link room to its successor", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	h.evacuateRoom(roomID, successorRoomID.String())
+
+	c.PureJSON(http.StatusCreated, schema.UpgradeRoomOutput{ID: successorRoomID.String()})
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"ask-me-anything/internal/schema"
+	"ask-me-anything/internal/store/pgstore"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+)
+
+// sessionTTL is how long an issued bearer token stays valid.
+const sessionTTL = 7 * 24 * time.Hour
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// bearerToken reads the caller's token from the Authorization header, or
+// from a ?token= query param as a fallback — browsers can't set custom
+// headers on a WebSocket handshake, so handleSubscribe relies on the latter.
+func bearerToken(c *gin.Context) string {
+	if after, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return after
+	}
+
+	return c.Query("token")
+}
+
+func (h *Handler) handleRegister(c *gin.Context) {
+	var body schema.RegisterInput
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(body.Password), bcrypt.DefaultCost)
+	if err != nil {
+		slog.Error("failed to hash password", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	userID, err := h.queries.InsertUser(c.Request.Context(), pgstore.InsertUserParams{
+		Email:        body.Email,
+		PasswordHash: string(passwordHash),
+	})
+	if err != nil {
+		slog.Error("failed to insert user", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.PureJSON(http.StatusCreated, schema.RegisterOutput{ID: userID.String()})
+}
+
+func (h *Handler) handleLogin(c *gin.Context) {
+	var body schema.LoginInput
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.PureJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.queries.GetUserByEmail(c.Request.Context(), body.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.PureJSON(http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+
+		slog.Error("failed to get user by email", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(body.Password)); err != nil {
+		c.PureJSON(http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		slog.Error("failed to generate session token", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err := h.queries.InsertSession(c.Request.Context(), pgstore.InsertSessionParams{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(sessionTTL), Valid: true},
+	}); err != nil {
+		slog.Error("failed to insert session", "error", err)
+		c.PureJSON(http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.PureJSON(http.StatusOK, schema.LoginOutput{Token: token})
+}
+
+// AuthMiddleware populates "user_id" in the context from the caller's bearer
+// token, rejecting the request if the token is missing, unknown, or expired.
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			c.PureJSON(http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+
+		session, err := h.queries.GetSessionByTokenHash(c.Request.Context(), hashToken(token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				c.PureJSON(http.StatusUnauthorized, "invalid or expired token")
+				c.Abort()
+				return
+			}
+
+			slog.Error("failed to get session", "error", err)
+			c.PureJSON(http.StatusInternalServerError, "something went wrong")
+			c.Abort()
+			return
+		}
+
+		if session.ExpiresAt.Time.Before(time.Now()) {
+			c.PureJSON(http.StatusUnauthorized, "invalid or expired token")
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", session.UserID.String())
+		c.Next()
+	}
+}
+
+// RequireRole gates a route to room members holding one of the given roles.
+// It reads the room ID from the :room_id URL param and the caller's
+// identity from the user_id AuthMiddleware set, so it must run after it.
+func (h *Handler) RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		allowed[role] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.PureJSON(http.StatusUnauthorized, "authentication required")
+			c.Abort()
+			return
+		}
+
+		roomID, err := uuid.Parse(c.Param("room_id"))
+		if err != nil {
+			c.PureJSON(http.StatusBadRequest, err.Error())
+			c.Abort()
+			return
+		}
+
+		member, err := h.queries.GetRoomMember(c.Request.Context(), pgstore.GetRoomMemberParams{
+			RoomID: roomID,
+			UserID: uuid.MustParse(userID.(string)),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				c.PureJSON(http.StatusForbidden, "not a member of this room")
+				c.Abort()
+				return
+			}
+
+			slog.Error("failed to get room member", "error", err)
+			c.PureJSON(http.StatusInternalServerError, "something went wrong")
+			c.Abort()
+			return
+		}
+
+		if _, ok := allowed[member.Role]; !ok {
+			c.PureJSON(http.StatusForbidden, "insufficient role")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// optionalUserID resolves the caller's user ID from their bearer token,
+// returning "" for an anonymous or invalid token rather than rejecting the
+// request — used by routes where authentication is optional, such as
+// handleSubscribe and the ACL ban check, rather than required.
+func (h *Handler) optionalUserID(c *gin.Context) string {
+	token := bearerToken(c)
+	if token == "" {
+		return ""
+	}
+
+	session, err := h.queries.GetSessionByTokenHash(c.Request.Context(), hashToken(token))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Error("failed to get session", "error", err)
+		}
+		return ""
+	}
+
+	if session.ExpiresAt.Time.Before(time.Now()) {
+		return ""
+	}
+
+	return session.UserID.String()
+}
@@ -0,0 +1,27 @@
+package api
+
+import "ask-me-anything/internal/schema"
+
+// Broker fans schema.Message events out to WebSocket subscribers of a room.
+// The default implementation only reaches subscribers connected to the same
+// process; PgBroker additionally relays events over Postgres LISTEN/NOTIFY so
+// a message published on one API instance also reaches subscribers connected
+// to another instance sitting behind the same load balancer.
+type Broker interface {
+	Publish(msg schema.Message)
+}
+
+// memoryBroker delivers messages straight to the handler's local subscribers.
+// It's the default broker and what every existing single-instance deployment
+// (and the test suite) keeps using.
+type memoryBroker struct {
+	handler *Handler
+}
+
+func newMemoryBroker(h *Handler) *memoryBroker {
+	return &memoryBroker{handler: h}
+}
+
+func (b *memoryBroker) Publish(msg schema.Message) {
+	b.handler.notifyClients(msg)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"ask-me-anything/internal/schema"
+	"ask-me-anything/internal/store/pgstore"
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TestPgBrokerDeliversAcrossInstances starts two Handlers, each backed by
+// its own PgBroker, against the same Postgres database and asserts that a
+// message published through one instance is delivered to a WebSocket
+// subscriber connected to the other — the scenario a single memoryBroker
+// can't handle once there's a load balancer in front of more than one
+// instance.
+//
+// It requires a real Postgres reachable at TEST_DATABASE_URL and is skipped
+// otherwise, since LISTEN/NOTIFY can't be faked with a mock.
+func TestPgBrokerDeliversAcrossInstances(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping broker integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	defer pool.Close()
+
+	queries := pgstore.New(pool)
+
+	connect := func(ctx context.Context) (*pgx.Conn, error) {
+		return pgx.Connect(ctx, dsn)
+	}
+
+	instanceA := NewHandler(queries).(*Handler)
+	instanceA.SetBroker(NewPgBroker(ctx, instanceA, connect))
+
+	instanceB := NewHandler(queries).(*Handler)
+	instanceB.SetBroker(NewPgBroker(ctx, instanceB, connect))
+
+	serverA := httptest.NewServer(instanceA)
+	defer serverA.Close()
+	serverB := httptest.NewServer(instanceB)
+	defer serverB.Close()
+
+	roomID, err := queries.InsertRoom(ctx, "cross-instance broker test")
+	if err != nil {
+		t.Fatalf("failed to insert room: %v", err)
+	}
+
+	// Subscribe on instance B and publish on instance A: the event has to
+	// travel through Postgres LISTEN/NOTIFY to reach this connection.
+	wsURL := "ws" + strings.TrimPrefix(serverB.URL, "http") + "/v1/subscribe/" + roomID.String()
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe on instance B: %v", err)
+	}
+	defer conn.Close()
+
+	// Give the LISTEN goroutine a moment to establish its dedicated
+	// connection before we publish.
+	time.Sleep(200 * time.Millisecond)
+
+	messageID, err := queries.InsertMessage(ctx, pgstore.InsertMessageParams{
+		RoomID:  roomID,
+		Message: "does this reach the other instance?",
+	})
+	if err != nil {
+		t.Fatalf("failed to insert message: %v", err)
+	}
+	instanceA.broker.Publish(schema.Message{
+		Kind:   KindMessageCreated,
+		RoomID: roomID.String(),
+		Value: schema.MessageCreatedEvent{
+			ID:      messageID.String(),
+			Message: "does this reach the other instance?",
+		},
+	})
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var msg schema.Message
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("instance B never received the event published on instance A: %v", err)
+	}
+
+	if msg.Kind != KindMessageCreated {
+		t.Errorf("kind = %q, want %q", msg.Kind, KindMessageCreated)
+	}
+}
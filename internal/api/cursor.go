@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// encodeCursor packs a message's (created_at, id) keyset position into an
+// opaque, base64-encoded cursor for pagination.
+func encodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := fmt.Sprintf("%s,%s", createdAt.Format(time.RFC3339Nano), id.String())
+	return base64.URLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor, rejecting anything malformed so a
+// tampered or stale cursor fails the request instead of silently
+// misbehaving.
+func decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), ",", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	return createdAt, id, nil
+}
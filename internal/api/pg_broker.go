@@ -0,0 +1,121 @@
+package api
+
+import (
+	"ask-me-anything/internal/schema"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// pgBrokerChannel is the single Postgres NOTIFY channel every instance
+// listens on; the room ID travels inside the JSON payload rather than in the
+// channel name so a single dedicated connection can serve every room.
+const pgBrokerChannel = "ama_broker_events"
+
+// pgBrokerPayload is the JSON sent over pgBrokerChannel. schema.Message keeps
+// RoomID out of its JSON form, so the room ID is carried beside it.
+type pgBrokerPayload struct {
+	RoomID  string         `json:"room_id"`
+	Message schema.Message `json:"message"`
+}
+
+// PgBroker publishes schema.Message events via Postgres LISTEN/NOTIFY so that
+// multiple API instances behind a load balancer share one fan-out: a message
+// posted on instance A is re-delivered to WebSocket subscribers connected to
+// instance B. Publish notifies over a short-lived connection; a background
+// goroutine holds a dedicated (non-pooled) connection for LISTEN, since pgx
+// refuses to run other queries on a connection that's listening, and
+// reconnects with backoff if that connection drops.
+type PgBroker struct {
+	handler *Handler
+	connect func(ctx context.Context) (*pgx.Conn, error)
+}
+
+// NewPgBroker starts the background LISTEN loop and returns a Broker whose
+// Publish issues a pg_notify on the shared channel. connect must return a
+// fresh, dedicated connection on every call, never one borrowed from a pool.
+func NewPgBroker(ctx context.Context, handler *Handler, connect func(ctx context.Context) (*pgx.Conn, error)) *PgBroker {
+	b := &PgBroker{handler: handler, connect: connect}
+	go b.listenLoop(ctx)
+	return b
+}
+
+func (b *PgBroker) Publish(msg schema.Message) {
+	payload, err := json.Marshal(pgBrokerPayload{RoomID: msg.RoomID, Message: msg})
+	if err != nil {
+		slog.Error("failed to marshal message for broker", "error", err)
+		return
+	}
+
+	go func() {
+		conn, err := b.connect(context.Background())
+		if err != nil {
+			slog.Error("failed to acquire connection to publish notification", "error", err)
+			return
+		}
+		defer conn.Close(context.Background())
+
+		if _, err := conn.Exec(context.Background(), "select pg_notify($1, $2)", pgBrokerChannel, string(payload)); err != nil {
+			slog.Error("failed to publish notification", "error", err)
+		}
+	}()
+}
+
+func (b *PgBroker) listenLoop(ctx context.Context) {
+	backoff := time.Second
+
+	for ctx.Err() == nil {
+		conn, err := b.connect(ctx)
+		if err != nil {
+			slog.Error("failed to open dedicated listen connection", "error", err)
+			time.Sleep(backoff)
+			backoff = nextBrokerBackoff(backoff)
+			continue
+		}
+
+		if _, err := conn.Exec(ctx, "listen "+pgBrokerChannel); err != nil {
+			slog.Error("failed to listen on broker channel", "error", err)
+			conn.Close(context.Background())
+			time.Sleep(backoff)
+			backoff = nextBrokerBackoff(backoff)
+			continue
+		}
+
+		backoff = time.Second
+		b.consume(ctx, conn)
+		conn.Close(context.Background())
+	}
+}
+
+func (b *PgBroker) consume(ctx context.Context, conn *pgx.Conn) {
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("lost connection to broker channel, reconnecting", "error", err)
+			return
+		}
+
+		var payload pgBrokerPayload
+		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
+			slog.Error("failed to unmarshal broker notification", "error", err)
+			continue
+		}
+
+		payload.Message.RoomID = payload.RoomID
+		b.handler.notifyClients(payload.Message)
+	}
+}
+
+func nextBrokerBackoff(d time.Duration) time.Duration {
+	const max = 30 * time.Second
+	if next := d * 2; next <= max {
+		return next
+	}
+	return max
+}
@@ -0,0 +1,19 @@
+package schema
+
+type RegisterInput struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type RegisterOutput struct {
+	ID string `json:"id"`
+}
+
+type LoginInput struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type LoginOutput struct {
+	Token string `json:"token"`
+}
@@ -28,11 +28,18 @@ type MessageAnsweredEvent struct {
 	ID string
 }
 
+// RoomClosedEvent is the final event written to every subscriber of a room
+// right before its connection is cancelled, so clients know where to go next.
+type RoomClosedEvent struct {
+	SuccessorRoomID string `json:"successor_room_id,omitempty"`
+}
+
 type GetMessageOutput struct {
 	ID            string `json:"id"`
 	Message       string `json:"theme"`
 	ReactionCount int64  `json:"reaction_count"`
 	Answered      bool   `json:"answered"`
+	Redacted      bool   `json:"redacted"`
 }
 
 type GetMessageByIDInput struct {
@@ -40,6 +47,48 @@ type GetMessageByIDInput struct {
 	MessageID string `uri:"message_id" binding:"required,uuid"`
 }
 
+// GetRoomMessagesQuery is the cursor-pagination query string for listing a
+// room's messages. Before and After are opaque cursors produced by
+// encodeCursor; at most one of them is expected to be set at a time.
+type GetRoomMessagesQuery struct {
+	Limit  int    `form:"limit"`
+	Before string `form:"before"`
+	After  string `form:"after"`
+}
+
+// GetRoomMessagesOutput wraps a page of messages with the cursors needed to
+// fetch the page before and after it.
+type GetRoomMessagesOutput struct {
+	Messages []GetMessageOutput `json:"messages"`
+	Next     string             `json:"next,omitempty"`
+	Prev     string             `json:"prev,omitempty"`
+}
+
+type GetTopRoomMessagesQuery struct {
+	Limit int `form:"limit"`
+}
+
 type ReactToMessageOutput struct {
 	Count int64 `json:"count"`
 }
+
+// RedactMessageInput carries the moderator's stated reason for redacting a
+// message. The reason is stored alongside the redaction and sent to live
+// subscribers in MessageRedactedEvent; the message read routes do not
+// return it.
+type RedactMessageInput struct {
+	Reason string `json:"reason"`
+}
+
+// MessageRedactedEvent tells live subscribers to blank a message's text in
+// place rather than removing it, preserving thread ordering.
+type MessageRedactedEvent struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// MessageConflictOutput is returned when an action targets a message that
+// has already been redacted, since redaction freezes its reactions.
+type MessageConflictOutput struct {
+	Error string `json:"error"`
+}
@@ -16,3 +16,38 @@ type GetRoomsOutput struct {
 type GetRoomByIDInput struct {
 	RoomID string `uri:"room_id" binding:"required,uuid"`
 }
+
+type CloseRoomInput struct {
+	SuccessorRoomID string `json:"successor_room_id" binding:"omitempty,uuid"`
+}
+
+type UpgradeRoomOutput struct {
+	ID string `json:"id"`
+}
+
+// RoomConflictOutput is returned when an action targets a room that has
+// already been closed, so clients can auto-redirect their participants.
+type RoomConflictOutput struct {
+	Error           string `json:"error"`
+	SuccessorRoomID string `json:"successor_room_id,omitempty"`
+}
+
+// PutRoomACLInput replaces a room's server_acls row. Allow and Deny are
+// path.Match-style globs matched against the client IP; BannedParticipants
+// is a separate deny-list of participant identifiers.
+type PutRoomACLInput struct {
+	Allow              []string `json:"allow"`
+	Deny               []string `json:"deny"`
+	BannedParticipants []string `json:"banned_participants"`
+}
+
+type GetRoomACLOutput struct {
+	Allow              []string `json:"allow"`
+	Deny               []string `json:"deny"`
+	BannedParticipants []string `json:"banned_participants"`
+}
+
+// ForbiddenOutput is returned when a room's ACL rejects the caller.
+type ForbiddenOutput struct {
+	Error string `json:"error"`
+}
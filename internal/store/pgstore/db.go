@@ -0,0 +1,25 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBTX is satisfied by both *pgxpool.Pool and a single *pgx.Conn, so Queries
+// can run against a pooled connection in the common case or a dedicated,
+// non-pooled connection when a caller needs one.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
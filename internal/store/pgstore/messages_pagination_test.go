@@ -0,0 +1,196 @@
+package pgstore
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeMessagesDB is a minimal DBTX that serves GetRoomMessagesPaginated out
+// of an in-memory, ascending (created_at, id) ordered slice, so the keyset
+// paging logic can be exercised without a real Postgres. It only supports
+// the query shapes GetRoomMessagesPaginated issues.
+type fakeMessagesDB struct {
+	messages []Message // sorted ascending by (created_at, id)
+}
+
+func (f *fakeMessagesDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeMessagesDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return nil
+}
+
+func keyLess(aTime time.Time, aID uuid.UUID, bTime time.Time, bID uuid.UUID) bool {
+	if aTime.Before(bTime) {
+		return true
+	}
+	if aTime.After(bTime) {
+		return false
+	}
+	return bytes.Compare(aID[:], bID[:]) < 0
+}
+
+func (f *fakeMessagesDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	after := args[1].(pgtype.Timestamptz)
+	afterID := args[2].(uuid.UUID)
+	before := args[3].(pgtype.Timestamptz)
+	beforeID := args[4].(uuid.UUID)
+	limit := int(args[5].(int32))
+
+	var page []Message
+	for _, m := range f.messages {
+		if after.Valid && !keyLess(after.Time, afterID, m.CreatedAt.Time, m.ID) {
+			continue
+		}
+		if before.Valid && !keyLess(m.CreatedAt.Time, m.ID, before.Time, beforeID) {
+			continue
+		}
+		page = append(page, m)
+	}
+
+	if sql == getRoomMessagesPaginatedBefore {
+		sort.Slice(page, func(i, j int) bool {
+			return keyLess(page[j].CreatedAt.Time, page[j].ID, page[i].CreatedAt.Time, page[i].ID)
+		})
+	}
+
+	if len(page) > limit {
+		page = page[:limit]
+	}
+
+	return &fakeMessageRows{data: page, idx: -1}, nil
+}
+
+type fakeMessageRows struct {
+	data []Message
+	idx  int
+}
+
+func (r *fakeMessageRows) Close()                                       {}
+func (r *fakeMessageRows) Err() error                                   { return nil }
+func (r *fakeMessageRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeMessageRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeMessageRows) RawValues() [][]byte                          { return nil }
+func (r *fakeMessageRows) Conn() *pgx.Conn                              { return nil }
+func (r *fakeMessageRows) Values() ([]any, error)                       { return nil, nil }
+
+func (r *fakeMessageRows) Next() bool {
+	r.idx++
+	return r.idx < len(r.data)
+}
+
+func (r *fakeMessageRows) Scan(dest ...any) error {
+	m := r.data[r.idx]
+	*dest[0].(*uuid.UUID) = m.ID
+	*dest[1].(*uuid.UUID) = m.RoomID
+	*dest[2].(*string) = m.Message
+	*dest[3].(*int64) = m.ReactionCount
+	*dest[4].(*bool) = m.Answered
+	*dest[5].(*pgtype.Timestamptz) = m.RedactedAt
+	*dest[6].(*pgtype.Timestamptz) = m.CreatedAt
+	return nil
+}
+
+func seedMessages(roomID uuid.UUID, n int) []Message {
+	base := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	messages := make([]Message, n)
+	for i := 0; i < n; i++ {
+		messages[i] = Message{
+			ID:        uuid.UUID{15: byte(i + 1)},
+			RoomID:    roomID,
+			Message:   "message",
+			CreatedAt: pgtype.Timestamptz{Time: base.Add(time.Duration(i) * time.Minute), Valid: true},
+		}
+	}
+	return messages
+}
+
+func idsOf(messages []Message) []uuid.UUID {
+	ids := make([]uuid.UUID, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+// TestGetRoomMessagesPaginatedForwardThenBackward walks forward through 30
+// messages in three pages of ten, then pages backward from the third page
+// using its own "prev" cursor (its first message) — which must land back on
+// the second page, not jump all the way to the first.
+func TestGetRoomMessagesPaginatedForwardThenBackward(t *testing.T) {
+	roomID := uuid.New()
+	all := seedMessages(roomID, 30)
+	q := New(&fakeMessagesDB{messages: all})
+	ctx := context.Background()
+
+	page1, err := q.GetRoomMessagesPaginated(ctx, GetRoomMessagesPaginatedParams{RoomID: roomID, Limit: 10})
+	if err != nil {
+		t.Fatalf("page1: %v", err)
+	}
+	if got, want := idsOf(page1), idsOf(all[0:10]); !uuidSlicesEqual(got, want) {
+		t.Fatalf("page1 = %v, want %v", got, want)
+	}
+
+	last := page1[len(page1)-1]
+	page2, err := q.GetRoomMessagesPaginated(ctx, GetRoomMessagesPaginatedParams{
+		RoomID: roomID, Limit: 10,
+		After:   last.CreatedAt,
+		AfterID: last.ID,
+	})
+	if err != nil {
+		t.Fatalf("page2: %v", err)
+	}
+	if got, want := idsOf(page2), idsOf(all[10:20]); !uuidSlicesEqual(got, want) {
+		t.Fatalf("page2 = %v, want %v", got, want)
+	}
+
+	last = page2[len(page2)-1]
+	page3, err := q.GetRoomMessagesPaginated(ctx, GetRoomMessagesPaginatedParams{
+		RoomID: roomID, Limit: 10,
+		After:   last.CreatedAt,
+		AfterID: last.ID,
+	})
+	if err != nil {
+		t.Fatalf("page3: %v", err)
+	}
+	if got, want := idsOf(page3), idsOf(all[20:30]); !uuidSlicesEqual(got, want) {
+		t.Fatalf("page3 = %v, want %v", got, want)
+	}
+
+	// Page backward from page3's own "prev" cursor (its first message) and
+	// expect page2 back, in ascending order — not page1, and not page3's
+	// rows reversed.
+	first := page3[0]
+	prevOfPage3, err := q.GetRoomMessagesPaginated(ctx, GetRoomMessagesPaginatedParams{
+		RoomID: roomID, Limit: 10,
+		Before:   first.CreatedAt,
+		BeforeID: first.ID,
+	})
+	if err != nil {
+		t.Fatalf("prevOfPage3: %v", err)
+	}
+	if got, want := idsOf(prevOfPage3), idsOf(page2); !uuidSlicesEqual(got, want) {
+		t.Fatalf("paging backward from page3 = %v, want page2 %v", got, want)
+	}
+}
+
+func uuidSlicesEqual(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package pgstore
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Room struct {
+	ID              uuid.UUID
+	Theme           string
+	ClosedAt        pgtype.Timestamptz
+	SuccessorRoomID uuid.NullUUID
+}
+
+type Message struct {
+	ID            uuid.UUID
+	RoomID        uuid.UUID
+	Message       string
+	ReactionCount int64
+	Answered      bool
+	RedactedAt    pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+}
+
+type User struct {
+	ID           uuid.UUID
+	Email        string
+	PasswordHash string
+}
+
+type Session struct {
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+}
+
+type RoomMember struct {
+	RoomID uuid.UUID
+	UserID uuid.UUID
+	Role   string
+}